@@ -0,0 +1,70 @@
+// Command goi18n-extract 扫描Go模块源码中对 T/TC/TData/TCData/GTF 的调用，生成/合并翻译人员使用的语言包文件
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	path "path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/JyCyunMe/go-i18n/i18n/extract"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "要扫描的Go模块根目录")
+	out := flag.String("out", "./lang/en.toml", "生成/合并的语言包文件路径")
+	tag := flag.String("tag", "en", "语言包文件头部标记的语言标签")
+	name := flag.String("name", "English", "语言包文件头部标记的语言名称")
+	flag.Parse()
+
+	messages, err := extract.ExtractDir(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[goi18n-extract] extract error: %v\n", err)
+		os.Exit(1)
+	}
+
+	marshal, unmarshal, err := formatFuncs(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[goi18n-extract] %v\n", err)
+		os.Exit(1)
+	}
+
+	header := fmt.Sprintf("# [i18n] <%s> <%s>", *tag, *name)
+	if err := extract.WritePack(*out, messages, header, marshal, unmarshal); err != nil {
+		fmt.Fprintf(os.Stderr, "[goi18n-extract] write error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[goi18n-extract] wrote %d message(s) to %s\n", len(messages), *out)
+}
+
+// formatFuncs 根据输出文件后缀选择序列化/反序列化方法 (输出文件路径)
+func formatFuncs(outPath string) (extract.Marshaler, extract.Unmarshaler, error) {
+	switch path.Ext(outPath) {
+	case ".toml":
+		return marshalToml, toml.Unmarshal, nil
+	case ".json":
+		return func(v map[string]string) ([]byte, error) {
+			return json.MarshalIndent(v, "", "  ")
+		}, json.Unmarshal, nil
+	case ".yaml", ".yml":
+		return func(v map[string]string) ([]byte, error) {
+			return yaml.Marshal(v)
+		}, yaml.Unmarshal, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported language pack format: %s", outPath)
+	}
+}
+
+// marshalToml 将消息集编码为TOML字节数据 (id -> 翻译文本)
+func marshalToml(v map[string]string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
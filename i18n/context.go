@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	goI18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type contextKey struct{}
+
+var langContextKey = contextKey{}
+
+// localizerCacheKey localizerCache 的键，同时包含 bundle 指针，使得重新 Init 产生新 bundle 后不会复用旧 bundle 的缓存
+type localizerCacheKey struct {
+	bundle *goI18n.Bundle
+	tag    string
+}
+
+// localizerCache 按 (bundle, 语言标记) 缓存的 *goI18n.Localizer，避免每个请求都重新创建
+var localizerCache sync.Map
+
+// loadedLanguageTags 记录已经载入 bundle 的 (bundle, 语言标记)，避免 FromContext 对同一语言重复 LoadLanguage
+var loadedLanguageTags sync.Map
+
+// WithLang 将指定语言写入 context，用于 HTTP 请求等场景下按用户区分语言 (上下文, 语言)
+func WithLang(ctx context.Context, lang *Lang) context.Context {
+	return context.WithValue(ctx, langContextKey, lang)
+}
+
+// langFromContext 从 context 中取出语言，取不到时回退到全局 Language (上下文)
+func langFromContext(ctx context.Context) *Lang {
+	if lang, ok := ctx.Value(langContextKey).(*Lang); ok && lang != nil {
+		return lang
+	}
+	langMu.RLock()
+	defer langMu.RUnlock()
+	return Language
+}
+
+// FromContext 获取 context 中语言对应的 *goI18n.Localizer，每个语言标记只创建一次 (上下文)
+func FromContext(ctx context.Context) *goI18n.Localizer {
+	lang := langFromContext(ctx)
+	if lang == nil {
+		return Localizer
+	}
+	langMu.RLock()
+	b := bundle
+	langMu.RUnlock()
+	key := localizerCacheKey{bundle: b, tag: lang.Tag.String()}
+	if cached, ok := localizerCache.Load(key); ok {
+		return cached.(*goI18n.Localizer)
+	}
+	ensureLanguageLoaded(lang)
+	localizer := goI18n.NewLocalizer(b, key.tag)
+	actual, _ := localizerCache.LoadOrStore(key, localizer)
+	return actual.(*goI18n.Localizer)
+}
+
+// ensureLanguageLoaded 确保 lang 对应的语言包已经载入 bundle，每个 (bundle, 语言) 只加载一次，
+// 使 FromContext 能正确返回非默认语言的本地化文本，而不是静默回退到 bundle 的默认语言 (语言)
+func ensureLanguageLoaded(lang *Lang) {
+	langMu.RLock()
+	key := localizerCacheKey{bundle: bundle, tag: lang.Tag.String()}
+	_, loaded := loadedLanguageTags.Load(key)
+	langMu.RUnlock()
+	if loaded {
+		return
+	}
+
+	langMu.Lock()
+	defer langMu.Unlock()
+	key = localizerCacheKey{bundle: bundle, tag: lang.Tag.String()}
+	if _, loaded := loadedLanguageTags.Load(key); loaded {
+		return
+	}
+	if err := LoadLanguage(lang); err == nil {
+		loadedLanguageTags.Store(key, struct{}{})
+	}
+}
+
+// localizeCtx 获取context对应语言下的本地化文本，未找到则使用默认文本 (上下文, 默认值, id, 变量map, 复数)
+func localizeCtx(ctx context.Context, defaultLocalized string, id string, data map[string]interface{}, pluralCount int) (localized string) {
+	localized, err := FromContext(ctx).Localize(&goI18n.LocalizeConfig{
+		DefaultMessage: &goI18n.Message{
+			ID: id,
+		},
+		TemplateData: data,
+		PluralCount:  pluralCount,
+	})
+	if err != nil {
+		logErrorFunc("[i18n] i18n error: %v", err)
+		return defaultLocalized
+	}
+	return localized
+}
+
+// TCtx 获取指定Id在context对应语言下的本地化文本 (上下文, id)
+func TCtx(ctx context.Context, id string) (localize string) {
+	return localizeCtx(ctx, "", id, nil, 0)
+}
+
+// TCtxData 获取指定Id在context对应语言下的本地化文本，使用i18n数据 (上下文, id, i18n数据)
+func TCtxData(ctx context.Context, id string, data *Data) (localize string) {
+	var localizeData map[string]interface{}
+	var pluralCount int
+	if data != nil {
+		localizeData = data.Data
+		pluralCount = data.PluralCount
+	}
+	return localizeCtx(ctx, "", id, localizeData, pluralCount)
+}
+
+// LanguageMiddleware 解析请求的 Accept-Language 头并将匹配到的语言注入 context，供 FromContext/TCtx/TCtxData 使用
+func LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lang := matchAcceptLanguage(r.Header.Get("Accept-Language")); lang != nil {
+			r = r.WithContext(WithLang(r.Context(), lang))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchAcceptLanguage 将 Accept-Language 头与已加载的语言包做匹配，选出最合适的语言 (Accept-Language头)
+func matchAcceptLanguage(header string) *Lang {
+	if len(header) == 0 {
+		return nil
+	}
+	langMu.RLock()
+	languages := Languages
+	langMu.RUnlock()
+	return matchSystemLanguage(header, languages)
+}
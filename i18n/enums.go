@@ -29,6 +29,9 @@ const (
 
 	// DefaultUseSystemLanguage 未获取到语言配置时是否使用系统语言
 	DefaultUseSystemLanguage
+
+	// WatchPackages 是否监听语言包文件变更并热重载
+	WatchPackages
 )
 
 var (
@@ -0,0 +1,179 @@
+// Package extract 提供从 Go 源码中提取可翻译字符串的能力，用于打通源码与翻译人员之间的工作流
+package extract
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// i18nPackageSuffix 目标 i18n 包的导入路径后缀，只有调用经类型检查确认来自该包才会被提取，
+// 避免误把同名的本地函数/方法（如某处自己写的 T()）当成 i18n 包的 T/TC/TData/TCData/GTF
+const i18nPackageSuffix = "/i18n"
+
+// funcIdArgIndex 记录各提取函数调用中，消息Id参数所在的位置
+var funcIdArgIndex = map[string]int{
+	"T":      0,
+	"TC":     1,
+	"TData":  0,
+	"TCData": 1,
+}
+
+// Message 从源码中提取到的一条待翻译消息
+type Message struct {
+
+	// Id 消息Id
+	Id string
+
+	// File 消息出现的源文件
+	File string
+
+	// Line 消息出现的行号
+	Line int
+}
+
+// ExtractDir 加载目录下的Go包并做类型检查，提取其中对 i18n 包 T/TC/TData/TCData/GTF 的调用 (目录)
+func ExtractDir(dir string) ([]Message, error) {
+	cfg := &packages.Config{
+		Dir:  dir,
+		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("[extract] load packages in %s error: %v", dir, err)
+	}
+
+	var messages []Message
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			messages = append(messages, extractFile(pkg.Fset, pkg.TypesInfo, file)...)
+		}
+	}
+	return dedup(messages), nil
+}
+
+// extractFile 从单个已做类型检查的Go源文件中提取消息 (FileSet, 类型信息, 语法树)
+func extractFile(fset *token.FileSet, info *types.Info, file *ast.File) []Message {
+	var messages []Message
+	filePath := fset.Position(file.Pos()).Filename
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fn := resolveI18nFunc(info, call.Fun)
+		if fn == nil {
+			return true
+		}
+		line := fset.Position(call.Pos()).Line
+		if fn.Name() == "GTF" {
+			if id := idFromConfigArg(call); len(id) > 0 {
+				messages = append(messages, Message{Id: id, File: filePath, Line: line})
+			}
+			return true
+		}
+		argIndex, ok := funcIdArgIndex[fn.Name()]
+		if !ok || argIndex >= len(call.Args) {
+			return true
+		}
+		if id, ok := stringLit(call.Args[argIndex]); ok {
+			messages = append(messages, Message{Id: id, File: filePath, Line: line})
+		}
+		return true
+	})
+	return messages
+}
+
+// resolveI18nFunc 借助类型信息解析调用表达式，只有确实解析到 i18n 包的顶层函数才返回 (类型信息, 调用的函数表达式)
+func resolveI18nFunc(info *types.Info, fun ast.Expr) *types.Func {
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		// 排除方法调用，例如某个结构体上恰好也有一个叫T的方法
+		return nil
+	}
+	if !strings.HasSuffix(fn.Pkg().Path(), i18nPackageSuffix) {
+		return nil
+	}
+	switch fn.Name() {
+	case "T", "TC", "TData", "TCData", "GTF":
+		return fn
+	default:
+		return nil
+	}
+}
+
+// stringLit 取出字符串字面量节点的值 (表达式)
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// idFromConfigArg 从 GTF(&I18nConfig{Id: "..."}) 调用中取出 Id 字段的值 (调用表达式)
+func idFromConfigArg(call *ast.CallExpr) string {
+	if len(call.Args) == 0 {
+		return ""
+	}
+	arg := call.Args[0]
+	if unary, ok := arg.(*ast.UnaryExpr); ok {
+		arg = unary.X
+	}
+	composite, ok := arg.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	for _, elt := range composite.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != "Id" {
+			continue
+		}
+		if id, ok := stringLit(kv.Value); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// dedup 对提取到的消息按Id去重，保留首次出现的位置，并按Id排序使输出稳定 (消息列表)
+func dedup(messages []Message) []Message {
+	seen := make(map[string]bool, len(messages))
+	result := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		if seen[m.Id] {
+			continue
+		}
+		seen[m.Id] = true
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Id < result[j].Id })
+	return result
+}
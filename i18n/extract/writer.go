@@ -0,0 +1,68 @@
+package extract
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+var i18nLabelRegexp = regexp.MustCompile(`# \[i18n\] <(.*)> <(.*)>`)
+
+// Marshaler 将消息集序列化为某种语言包格式的字节数据 (id -> 翻译文本)
+type Marshaler func(v map[string]string) ([]byte, error)
+
+// Unmarshaler 将语言包字节数据反序列化为消息集，对应 bundle.RegisterUnmarshalFunc 的函数签名
+type Unmarshaler func(p []byte, v interface{}) error
+
+// WritePack 将提取到的消息合并写入语言包文件，提取到的Id若已有非空翻译则予以保留，否则以Id自身作为占位翻译；已有文件中不再被提取到的Id不会写出 (输出文件路径, 消息列表, 头部标记行, 序列化方法, 反序列化方法)
+func WritePack(outPath string, messages []Message, header string, marshal Marshaler, unmarshal Unmarshaler) error {
+	existing := map[string]string{}
+	if data, err := os.ReadFile(outPath); err == nil {
+		if err := unmarshal(stripHeader(data), &existing); err != nil {
+			return fmt.Errorf("[extract] parse existing pack %s error: %v", outPath, err)
+		}
+	}
+
+	merged := make(map[string]string, len(messages))
+	for _, m := range messages {
+		if translated, ok := existing[m.Id]; ok && len(translated) > 0 {
+			merged[m.Id] = translated
+		} else {
+			merged[m.Id] = m.Id
+		}
+	}
+
+	body, err := marshal(merged)
+	if err != nil {
+		return fmt.Errorf("[extract] marshal %s error: %v", outPath, err)
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("[extract] create %s error: %v", outPath, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if _, err := w.WriteString(header + "\n\n"); err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// stripHeader 去掉语言包文件首行的 "# [i18n] <tag> <name>" 标记行，便于按格式反序列化剩余内容 (文件内容)
+func stripHeader(data []byte) []byte {
+	if !i18nLabelRegexp.Match(data) {
+		return data
+	}
+	for i, b := range data {
+		if b == '\n' {
+			return data[i+1:]
+		}
+	}
+	return data
+}
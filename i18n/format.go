@@ -0,0 +1,29 @@
+package i18n
+
+import (
+	"encoding/json"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// formatUnmarshalFuncs 格式名 -> 反序列化方法，内置注册，支持通过 RegisterFormat 追加或覆盖
+var formatUnmarshalFuncs map[string]func(p []byte, v interface{}) error
+
+func init() {
+	formatUnmarshalFuncs = map[string]func(p []byte, v interface{}) error{
+		"toml": toml.Unmarshal,
+		"json": json.Unmarshal,
+		"yaml": yaml.Unmarshal,
+	}
+}
+
+// RegisterFormat 注册语言包格式对应的反序列化方法，使一个项目可以混用多种格式的语言包文件 (格式名, 反序列化方法)
+func RegisterFormat(format string, fn func(p []byte, v interface{}) error) {
+	langMu.Lock()
+	defer langMu.Unlock()
+	formatUnmarshalFuncs[format] = fn
+	if bundle != nil {
+		bundle.RegisterUnmarshalFunc(format, fn)
+	}
+}
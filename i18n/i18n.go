@@ -9,6 +9,7 @@ import (
 	path "path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/BurntSushi/toml"
@@ -16,107 +17,16 @@ import (
 	"golang.org/x/text/language"
 )
 
-// Lang 语言
-type Lang struct {
-
-	// Name 语言名称
-	Name string
-
-	// Tag 语言标记
-	Tag language.Tag
-
-	// FileName 语言包文件名
-	FileName string
-
-	// Data 数据
-	Data *[]byte
-}
-
-// Data i18n数据
-type Data struct {
-
-	// Data 变量map
-	Data map[string]interface{}
-
-	// PluralCount 复数
-	PluralCount int
-}
-
-// CallbackData 回调数据
-type CallbackData struct {
-	// 回调方法
-	Callback func(params ...interface{})
-
-	// 回调Id
-	CallbackId uint32
-	// 非原始回调标识 (内部用)
-	notOrigin bool
-}
-
-// I18nConfig i18n配置
-type I18nConfig struct {
-
-	// Id
-	Id string
-
-	// Format 可变格式
-	Format string
-
-	// Data i18n数据
-	Data *Data
-
-	// CallbackData 回调数据
-	//CallbackData    CallbackData
-
-}
-
-// OptionType 选项类型
-type OptionType int8
-
-// Option 选项
-type Option struct {
-
-	// OptionType 选项类型
-	OptionType OptionType
-
-	// Data 选项数据
-	Data *interface{}
-
-	// Callback 选项回调
-	Callback func(v interface{}) interface{}
-}
-
-const (
-
-	// OptionType 选项类型枚举
-
-	// LogInfoFunc Info日志方法
-	LogInfoFunc OptionType = iota + 1
-
-	// LogErrorFunc Error日志方法
-	LogErrorFunc
-
-	// UnmarshalFunc 语言包解码方法
-	UnmarshalFunc
-
-	// PackageSuffix 语言包格式名
-	PackageSuffix
-
-	// PackagePath 语言包路径
-	PackagePath
-
-	// PackagePattern 语言包路径正则表达式
-	PackagePattern
-
-	// PackageListFunc 语言包列表方法
-	PackageListFunc
-)
-
 var (
 	callbackDataMap       map[uint32]*CallbackData
 	currentCallbackDataId uint32
+	// callbackMu 保护 callbackDataMap 的并发读写
+	callbackMu sync.RWMutex
 )
 
+// langMu 保护 Language/Languages/bundle 的并发读写，供语言包热重载等场景使用
+var langMu sync.RWMutex
+
 var (
 	Localizer *goI18n.Localizer
 	// 语言集
@@ -136,12 +46,6 @@ var (
 	i18nLabelRegexp *regexp.Regexp
 )
 
-var (
-	SimplifiedChinese  = Lang{Name: "zh-Hans", Tag: language.SimplifiedChinese}
-	TraditionalChinese = Lang{Name: "zh-Hans", Tag: language.TraditionalChinese}
-	English            = Lang{Name: "en", Tag: language.English}
-)
-
 func init() {
 	var err error
 	i18nLabelRegexp, err = regexp.Compile(`# \[i18n\] <(.*)> <(.*)>`)
@@ -254,7 +158,11 @@ func InitI18nWithOptions(lang *Lang, options ...Option) error {
 	unmarshalFunc = toml.Unmarshal
 	var pListFunc func(options ...Option) ([]*Lang, error)
 	format := "lang"
-	langFilesPattern := path.Join("./lang/*." + format)
+	packageDir := "./lang"
+	langFilesPattern := path.Join(packageDir, "*."+format)
+	watchPackages := false
+	useSystemLanguage := false
+	explicitPattern := false
 
 	existPackagePath := false
 	for _, option := range options {
@@ -285,15 +193,17 @@ func InitI18nWithOptions(lang *Lang, options ...Option) error {
 		case PackageSuffix:
 			format = (*option.Data).(string)
 			if existPackagePath {
-				langFilesPattern = path.Join((*option.Data).(string), "*."+format)
+				langFilesPattern = path.Join(packageDir, "*."+format)
 			}
 			break
 		case PackagePath:
-			langFilesPattern = path.Join((*option.Data).(string), "*."+format)
+			packageDir = (*option.Data).(string)
+			langFilesPattern = path.Join(packageDir, "*."+format)
 			existPackagePath = true
 			break
 		case PackagePattern:
 			langFilesPattern = (*option.Data).(string)
+			explicitPattern = true
 			break
 		case PackageListFunc:
 			pListFunc = func(options ...Option) (l []*Lang, e error) {
@@ -308,28 +218,66 @@ func InitI18nWithOptions(lang *Lang, options ...Option) error {
 				return
 			}
 			break
+		case WatchPackages:
+			watchPackages = (*option.Data).(bool)
+			break
+		case DefaultUseSystemLanguage:
+			useSystemLanguage = (*option.Data).(bool)
+			break
 		default:
 			continue
 		}
 	}
+	// formats 本次初始化最终生效的格式名 -> 反序列化方法，内置 json/yaml/toml，再加上 PackageSuffix 指定的格式（默认 lang）及 unmarshalFunc（默认 toml.Unmarshal），与内置格式同名时覆盖内置方法
+	formats := make(map[string]func(p []byte, v interface{}) error, len(formatUnmarshalFuncs)+1)
+	for f, fn := range formatUnmarshalFuncs {
+		formats[f] = fn
+	}
+	formats[format] = unmarshalFunc
+
+	var patterns []string
+	if explicitPattern {
+		patterns = []string{langFilesPattern}
+	} else {
+		for f := range formats {
+			patterns = append(patterns, path.Join(packageDir, "*."+f))
+		}
+	}
+
 	if pListFunc == nil {
 		packageListFunc = func(options ...Option) ([]*Lang, error) {
-			return PackageListByPatternFunc(NewOptionWithData(PackagePattern, langFilesPattern))
+			seenFiles := make(map[string]bool)
+			var all []*Lang
+			for _, pattern := range patterns {
+				langs, err := PackageListByPatternFunc(NewOptionWithData(PackagePattern, pattern))
+				if err != nil {
+					return nil, err
+				}
+				for _, l := range langs {
+					if seenFiles[l.FileName] {
+						continue
+					}
+					seenFiles[l.FileName] = true
+					all = append(all, l)
+				}
+			}
+			return all, nil
 		}
 	} else {
 		packageListFunc = func(options ...Option) ([]*Lang, error) {
 			return pListFunc(NewOptionWithData(PackagePattern, langFilesPattern))
 		}
 	}
-	if lang == nil {
-		lang = &English
-		logInfoFunc("[i18n] Not special language, default using %s (%s)", lang.Name, lang.Tag.String())
+	bundleTag := English.Tag
+	if lang != nil {
+		bundleTag = lang.Tag
 	}
-	SetDefaultLang(*lang)
 	logInfoFunc("[i18n] InitI18n started")
-	bundle = goI18n.NewBundle(lang.Tag)
-	bundle.RegisterUnmarshalFunc(format, unmarshalFunc)
-	logInfoFunc("[i18n] Registered unmarshal func for %s", format)
+	bundle = goI18n.NewBundle(bundleTag)
+	for f, fn := range formats {
+		bundle.RegisterUnmarshalFunc(f, fn)
+		logInfoFunc("[i18n] Registered unmarshal func for %s", f)
+	}
 	packageList, err := packageListFunc()
 	if err != nil {
 		panic(fmt.Sprintf("[i18n] PackageList error: %v", err))
@@ -339,6 +287,21 @@ func InitI18nWithOptions(lang *Lang, options ...Option) error {
 		logErrorFunc("[i18n] Cannot load any language")
 		panic("[i18n] Cannot load any language")
 	}
+	if lang == nil {
+		if useSystemLanguage {
+			if locale := detectSystemLanguage(); len(locale) > 0 {
+				if matched := matchSystemLanguage(locale, Languages); matched != nil {
+					lang = matched
+					logInfoFunc("[i18n] Detected system language \"%s\", using %s", locale, lang.FullName())
+				}
+			}
+		}
+		if lang == nil {
+			lang = &English
+			logInfoFunc("[i18n] Not special language, default using %s (%s)", lang.Name, lang.Tag.String())
+		}
+	}
+	SetDefaultLang(*lang)
 	for _, l := range Languages {
 		//if Language == nil && DefaultLang != nil && DefaultLang.Tag == l.Tag {
 		if lang.Tag == l.Tag {
@@ -349,6 +312,11 @@ func InitI18nWithOptions(lang *Lang, options ...Option) error {
 			break
 		}
 	}
+	if watchPackages {
+		if err := StartWatch(langFilesPattern); err != nil {
+			return err
+		}
+	}
 	logInfoFunc("[i18n] InitI18n finished")
 	return nil
 }
@@ -377,6 +345,11 @@ func PackageListByPatternFunc(options ...Option) ([]*Lang, error) {
 	}
 	var languages []*Lang
 	for _, langFile := range packages {
+		// fsnotify 监听的是 watchDirsFromPattern 解析出的绝对目录，事件路径也是绝对的，
+		// 这里统一转换为绝对路径，使 reloadLanguageFile 里的 FileName 比较能够命中
+		if abs, err := path.Abs(langFile); err == nil {
+			langFile = abs
+		}
 		lang := ReadLangFromFileName(langFile)
 		if lang == nil {
 			continue
@@ -391,11 +364,18 @@ func PackageListByPatternFunc(options ...Option) ([]*Lang, error) {
 
 func LoadLanguage(lang *Lang) (err error) {
 	langFile := lang.FileName
-	if lang.Data != nil {
-		_, err = bundle.ParseMessageFileBytes(*lang.Data, langFile)
-	} else {
-		_, err = bundle.LoadMessageFile(langFile)
+	data := lang.Data
+	if data == nil {
+		var fileData []byte
+		fileData, err = os.ReadFile(langFile)
+		if err != nil {
+			err = fmt.Errorf("[i18n] Load language file \"%s\" error: %v", lang.FullName(), err)
+			logErrorFunc(err.Error())
+			return err
+		}
+		data = &fileData
 	}
+	_, err = bundle.ParseMessageFileBytes(stripI18nLabel(*data), langFile)
 	if err != nil {
 		err = fmt.Errorf("[i18n] Load language file \"%s\" error: %v", lang.FullName(), err)
 		logErrorFunc(err.Error())
@@ -404,8 +384,23 @@ func LoadLanguage(lang *Lang) (err error) {
 	return nil
 }
 
+// stripI18nLabel 去掉语言包文件首行的 "# [i18n] <tag> <name>" 标记行，避免该行在 JSON 等没有注释语法的格式里导致解析失败 (文件内容)
+func stripI18nLabel(data []byte) []byte {
+	if !i18nLabelRegexp.Match(data) {
+		return data
+	}
+	for i, b := range data {
+		if b == '\n' {
+			return data[i+1:]
+		}
+	}
+	return data
+}
+
 func UseLanguage(lang *Lang) (err error) {
 	//if Language == nil && DefaultLang != nil && DefaultLang.Tag == lang.Tag {
+	langMu.Lock()
+	defer langMu.Unlock()
 	Language = lang
 	err = LoadLanguage(lang)
 	if err != nil {
@@ -498,6 +493,8 @@ func TCDataCallback(defaultLocalized string, id string, data *Data, callback fun
 
 // Localize *获取本地化文本，使用变量map和复数，未找到则使用默认文本 (默认值, id, 变量map, 复数)
 func Localize(defaultLocalized string, id string, data map[string]interface{}, pluralCount int) (localized string) {
+	langMu.RLock()
+	defer langMu.RUnlock()
 	localized, err := Localizer.Localize(&goI18n.LocalizeConfig{
 		DefaultMessage: &goI18n.Message{
 			ID: id,
@@ -514,14 +511,18 @@ func Localize(defaultLocalized string, id string, data map[string]interface{}, p
 
 // SwitchLanguage 切换语言 (语言)
 func SwitchLanguage(lang *Lang) (err error) {
+	langMu.Lock()
 	err = LoadLanguage(lang)
 	if err != nil {
+		langMu.Unlock()
 		return err
 	}
 	Localizer = goI18n.NewLocalizer(bundle, lang.Tag.String())
 	Language = lang
+	langMu.Unlock()
 	logInfoFunc("[i18n] switched to new language: %s", lang.FullName())
-	for _, callbackData := range callbackDataMap {
+
+	for _, callbackData := range snapshotSwitchCallbacks() {
 		if callbackData != nil && callbackData.Callback != nil {
 			callbackData.Callback()
 		}
@@ -535,16 +536,44 @@ func AddSwitchCallbackDo(data *CallbackData) {
 	data.Callback()
 }
 
-// AddSwitchCallback 添加切换语言自动回调 (回调数据)
-func AddSwitchCallback(data *CallbackData) {
-	if data, exist := callbackDataMap[data.CallbackId]; exist {
-		if !data.notOrigin {
+// AddSwitchCallback 添加切换语言自动回调，返回分配的回调Id (回调数据)
+func AddSwitchCallback(data *CallbackData) uint32 {
+	callbackMu.Lock()
+	defer callbackMu.Unlock()
+	if existing, exist := callbackDataMap[data.CallbackId]; exist {
+		if !existing.notOrigin {
 			logErrorFunc("[i18n] cannot add duplicated callback")
 		}
-		return
+		return existing.CallbackId
+	}
+	data.CallbackId = getNewCallbackDataId()
+	callbackDataMap[data.CallbackId] = data
+	return data.CallbackId
+}
+
+// RemoveSwitchCallback 移除指定Id的切换语言回调 (回调Id)
+func RemoveSwitchCallback(id uint32) {
+	callbackMu.Lock()
+	delete(callbackDataMap, id)
+	callbackMu.Unlock()
+}
+
+// ClearSwitchCallbacks 清空所有切换语言回调
+func ClearSwitchCallbacks() {
+	callbackMu.Lock()
+	callbackDataMap = make(map[uint32]*CallbackData)
+	callbackMu.Unlock()
+}
+
+// snapshotSwitchCallbacks 拍下当前回调表的快照，用于在派发回调时允许回调自身注册/移除回调
+func snapshotSwitchCallbacks() []*CallbackData {
+	callbackMu.RLock()
+	defer callbackMu.RUnlock()
+	snapshot := make([]*CallbackData, 0, len(callbackDataMap))
+	for _, callbackData := range callbackDataMap {
+		snapshot = append(snapshot, callbackData)
 	}
-	getNewCallbackDataId()
-	callbackDataMap[currentCallbackDataId] = data
+	return snapshot
 }
 
 func getNewCallbackDataId() uint32 {
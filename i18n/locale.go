@@ -0,0 +1,30 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+// detectSystemLanguage 探测操作系统当前的语言环境，探测失败时返回空字符串
+func detectSystemLanguage() string {
+	return systemLocale()
+}
+
+// matchSystemLanguage 将探测到的系统语言环境与已加载的语言包做匹配，选出最合适的语言，没有可匹配的语言时返回 nil (系统语言环境, 候选语言包)
+func matchSystemLanguage(locale string, languages []*Lang) *Lang {
+	if len(locale) == 0 || len(languages) == 0 {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(locale)
+	if err != nil || len(tags) == 0 {
+		return nil
+	}
+
+	candidateTags := make([]language.Tag, len(languages))
+	for i, l := range languages {
+		candidateTags[i] = l.Tag
+	}
+	matcher := language.NewMatcher(candidateTags)
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return nil
+	}
+	return languages[index]
+}
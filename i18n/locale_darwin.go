@@ -0,0 +1,23 @@
+//go:build darwin
+
+package i18n
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// systemLocale 读取 macOS 的 AppleLanguages 偏好设置获取系统语言，读取失败时回退到环境变量
+func systemLocale() string {
+	out, err := exec.Command("defaults", "read", "-g", "AppleLanguages").Output()
+	if err == nil {
+		text := strings.Trim(string(out), "()\n \t")
+		for _, item := range strings.Split(text, ",") {
+			lang := strings.Trim(strings.TrimSpace(item), "\"")
+			if len(lang) > 0 {
+				return normalizeLocale(lang)
+			}
+		}
+	}
+	return systemLocaleFromEnv()
+}
@@ -0,0 +1,27 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+)
+
+// systemLocaleFromEnv 依次读取 LC_ALL / LC_MESSAGES / LANG 环境变量获取系统语言环境
+func systemLocaleFromEnv() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(key); len(v) > 0 {
+			return normalizeLocale(v)
+		}
+	}
+	return ""
+}
+
+// normalizeLocale 去掉类似 "en_US.UTF-8" 中的编码/变体后缀，转换为 BCP 47 可解析的形式
+func normalizeLocale(raw string) string {
+	if i := strings.IndexByte(raw, '.'); i >= 0 {
+		raw = raw[:i]
+	}
+	if i := strings.IndexByte(raw, '@'); i >= 0 {
+		raw = raw[:i]
+	}
+	return strings.ReplaceAll(raw, "_", "-")
+}
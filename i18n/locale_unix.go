@@ -0,0 +1,8 @@
+//go:build !windows && !darwin
+
+package i18n
+
+// systemLocale 读取类 Unix 系统的语言环境变量获取系统语言
+func systemLocale() string {
+	return systemLocaleFromEnv()
+}
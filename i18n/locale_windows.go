@@ -0,0 +1,31 @@
+//go:build windows
+
+package i18n
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// localeNameMaxLength 对应 Win32 常量 LOCALE_NAME_MAX_LENGTH
+const localeNameMaxLength = 85
+
+var (
+	kernel32                     = windows.NewLazySystemDLL("kernel32.dll")
+	procGetUserDefaultLocaleName = kernel32.NewProc("GetUserDefaultLocaleName")
+)
+
+// systemLocale 调用 Windows API 获取用户默认语言区域名称，探测失败时回退到环境变量
+func systemLocale() string {
+	buf := make([]uint16, localeNameMaxLength)
+	ret, _, _ := procGetUserDefaultLocaleName.Call(
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)),
+	)
+	if ret == 0 {
+		return systemLocaleFromEnv()
+	}
+	return syscall.UTF16ToString(buf)
+}
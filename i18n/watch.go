@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	path "path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	goI18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// watchDebounceInterval 语言包文件变更的去抖间隔，避免编辑器连续写入触发多次重载
+const watchDebounceInterval = 300 * time.Millisecond
+
+var (
+	watcher   *fsnotify.Watcher
+	watchDone chan struct{}
+
+	watchTimersMu sync.Mutex
+	watchTimers   map[string]*time.Timer
+)
+
+// StartWatch 启动语言包文件监听，文件变更时自动重新解析并刷新 bundle (语言包路径模式)
+func StartWatch(pattern string) error {
+	if watcher != nil {
+		return nil
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		logErrorFunc("[i18n] StartWatch NewWatcher error: %v", err)
+		return err
+	}
+
+	dirs, err := watchDirsFromPattern(pattern)
+	if err != nil {
+		logErrorFunc("[i18n] StartWatch watchDirsFromPattern error: %v", err)
+		return err
+	}
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			logErrorFunc("[i18n] StartWatch watch dir \"%s\" error: %v", dir, err)
+			return err
+		}
+	}
+
+	watcher = w
+	watchDone = make(chan struct{})
+	watchTimers = make(map[string]*time.Timer)
+
+	go watchLoop()
+	logInfoFunc("[i18n] StartWatch watching %d director(y/ies) for pattern \"%s\"", len(dirs), pattern)
+	return nil
+}
+
+// StopWatch 停止语言包文件监听
+func StopWatch() {
+	if watcher == nil {
+		return
+	}
+	close(watchDone)
+	_ = watcher.Close()
+	watcher = nil
+
+	watchTimersMu.Lock()
+	for _, timer := range watchTimers {
+		timer.Stop()
+	}
+	watchTimers = nil
+	watchTimersMu.Unlock()
+
+	logInfoFunc("[i18n] StopWatch stopped")
+}
+
+func watchLoop() {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			scheduleReload(event.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logErrorFunc("[i18n] watch error: %v", err)
+		case <-watchDone:
+			return
+		}
+	}
+}
+
+// scheduleReload 对同一文件的连续写入进行去抖，最终只触发一次重载
+func scheduleReload(file string) {
+	watchTimersMu.Lock()
+	defer watchTimersMu.Unlock()
+	if watchTimers == nil {
+		return
+	}
+	if timer, exist := watchTimers[file]; exist {
+		timer.Stop()
+	}
+	watchTimers[file] = time.AfterFunc(watchDebounceInterval, func() {
+		reloadLanguageFile(file)
+	})
+}
+
+// reloadLanguageFile 重新解析变更的语言包文件，并在它就是当前使用语言时刷新 Localizer 及回调
+func reloadLanguageFile(file string) {
+	langMu.RLock()
+	languages := Languages
+	current := Language
+	langMu.RUnlock()
+
+	var lang *Lang
+	for _, l := range languages {
+		if l.FileName == file {
+			lang = l
+			break
+		}
+	}
+	if lang == nil {
+		return
+	}
+
+	langMu.Lock()
+	err := LoadLanguage(lang)
+	isCurrent := current != nil && current.Tag == lang.Tag
+	if isCurrent {
+		Localizer = goI18n.NewLocalizer(bundle, lang.Tag.String())
+	}
+	langMu.Unlock()
+	if err != nil {
+		logErrorFunc("[i18n] reloadLanguageFile reload \"%s\" error: %v", file, err)
+		return
+	}
+	logInfoFunc("[i18n] reloadLanguageFile reloaded %s", lang.FullName())
+
+	if isCurrent {
+		for _, callbackData := range snapshotSwitchCallbacks() {
+			if callbackData != nil && callbackData.Callback != nil {
+				callbackData.Callback()
+			}
+		}
+	}
+}
+
+// watchDirsFromPattern 提取 glob 模式中的目录集合，用于注册 fsnotify 监听 (路径模式)
+func watchDirsFromPattern(pattern string) ([]string, error) {
+	dir := path.Dir(pattern)
+	abs, err := path.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{abs}, nil
+}